@@ -0,0 +1,31 @@
+// access.go
+package auth
+
+import "context"
+
+// Resource identifies the thing an action is performed against, e.g.
+// Resource{Type: "repository", Name: "foo"}.
+type Resource struct {
+	Type string
+	Name string
+}
+
+// Access is a single permission requirement: performing Action on Resource,
+// e.g. {Resource{"repository", "foo"}, "pull"}.
+type Access struct {
+	Resource Resource
+	Action   string
+}
+
+// AccessController authorizes a set of Access requirements, independent of
+// how the caller was authenticated. Modeled after the access controller in
+// Docker's distribution registry: callers ask "am I allowed to do all of
+// these things" rather than "do I have this role".
+type AccessController interface {
+	// Authorized checks whether the subject already present in ctx (see
+	// UserFromContext) may perform every requested Access. It returns a
+	// context carrying any additional authorization info on success,
+	// ErrMissingCredentials when ctx carries no user, or an error matching
+	// ErrAccessDenied (errors.Is) when an Access is not granted.
+	Authorized(ctx context.Context, access ...Access) (context.Context, error)
+}
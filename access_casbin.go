@@ -0,0 +1,48 @@
+// access_casbin.go
+package auth
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/casbin/casbin/v2"
+)
+
+// CasbinAccessController is an AccessController backed by a Casbin enforcer,
+// for deployments that already model authorization with Casbin policies
+// (RBAC, ABAC, or custom matchers) instead of the built-in RBACAccessController.
+type CasbinAccessController struct {
+	enforcer *casbin.Enforcer
+}
+
+// NewCasbinAccessController wraps an already-configured casbin.Enforcer. The
+// enforcer's model is expected to accept (subject, resourceType:resourceName,
+// action) requests; see casbin's RBAC model examples for a starting point.
+func NewCasbinAccessController(enforcer *casbin.Enforcer) *CasbinAccessController {
+	return &CasbinAccessController{enforcer: enforcer}
+}
+
+// Authorized implements AccessController.
+func (c *CasbinAccessController) Authorized(ctx context.Context, access ...Access) (context.Context, error) {
+	user, ok := UserFromContext(ctx)
+	if !ok {
+		return ctx, ErrMissingCredentials
+	}
+
+	for _, a := range access {
+		resource := a.Resource.Type + ":" + a.Resource.Name
+
+		ok, err := c.enforcer.Enforce(user.Username, resource, a.Action)
+		if err != nil {
+			return ctx, AuthError{Code: CodeAccessDenied, StatusCode: http.StatusInternalServerError, Message: "casbin enforcement failed", Err: err}
+		}
+		if !ok {
+			return ctx, ErrAccessDenied.WithDetails(map[string]any{
+				"resource": resource,
+				"action":   a.Action,
+			})
+		}
+	}
+
+	return ctx, nil
+}
@@ -0,0 +1,76 @@
+// access_rbac_test.go
+package auth
+
+import (
+	"context"
+	"testing"
+)
+
+func TestRBACAccessControllerAuthorized(t *testing.T) {
+	controller := NewRBACAccessController(map[string][]AccessRule{
+		"admin": {
+			{ResourceType: "*", ResourceName: "*", Action: "*"},
+		},
+		"reader": {
+			{ResourceType: "repository", ResourceName: "*", Action: "pull"},
+		},
+		"writer": {
+			{ResourceType: "repository", ResourceName: "foo", Action: "push"},
+		},
+	})
+
+	tests := []struct {
+		name    string
+		roles   []string
+		access  Access
+		wantErr bool
+	}{
+		{
+			name:    "admin can do anything",
+			roles:   []string{"admin"},
+			access:  Access{Resource: Resource{Type: "repository", Name: "foo"}, Action: "push"},
+			wantErr: false,
+		},
+		{
+			name:    "reader can pull any repository",
+			roles:   []string{"reader"},
+			access:  Access{Resource: Resource{Type: "repository", Name: "bar"}, Action: "pull"},
+			wantErr: false,
+		},
+		{
+			name:    "reader cannot push",
+			roles:   []string{"reader"},
+			access:  Access{Resource: Resource{Type: "repository", Name: "bar"}, Action: "push"},
+			wantErr: true,
+		},
+		{
+			name:    "writer can push its own repository",
+			roles:   []string{"writer"},
+			access:  Access{Resource: Resource{Type: "repository", Name: "foo"}, Action: "push"},
+			wantErr: false,
+		},
+		{
+			name:    "writer cannot push a different repository",
+			roles:   []string{"writer"},
+			access:  Access{Resource: Resource{Type: "repository", Name: "bar"}, Action: "push"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown role is denied",
+			roles:   []string{"nobody"},
+			access:  Access{Resource: Resource{Type: "repository", Name: "foo"}, Action: "pull"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := SetUserContext(context.Background(), &AuthenticatedUser{Username: "u", Roles: tt.roles})
+
+			_, err := controller.Authorized(ctx, tt.access)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Authorized() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -29,11 +29,25 @@ type Authenticator interface {
 	// WriteCredentials write creddentials in the HTTP response (headers, cookies, etc.)
 	WriteCredentials(w http.ResponseWriter, creds interface{}) error
 
+	// RefreshCredentials exchanges a refresh token for a new authenticated user
+	// and a new set of credentials, rotating the refresh token if the
+	// implementation supports rotation-on-use.
+	RefreshCredentials(ctx context.Context, refreshToken interface{}) (*AuthenticatedUser, interface{}, error)
+
+	// RevokeCredentials invalidates creds (and any associated refresh token),
+	// e.g. for logout-everywhere.
+	RevokeCredentials(ctx context.Context, creds interface{}) error
+
 	// Middleware return middleware  HTTP for authentication
 	Middleware() func(http.Handler) http.Handler
 
 	// MiddlewareWithRoles return middleware  retorna um middleware which also checks roles/permissions
 	MiddlewareWithRoles(roles ...string) func(http.Handler) http.Handler
+
+	// MiddlewareWithAccess returns a middleware that authorizes the request
+	// against the given Access requirements via an AccessController, for
+	// per-resource checks finer-grained than MiddlewareWithRoles.
+	MiddlewareWithAccess(access ...Access) func(http.Handler) http.Handler
 }
 
 // AuthOptions general settings for any authenticator
@@ -41,8 +55,12 @@ type AuthOptions struct {
 	// standard duration for credentials
 	CredentialsDuration time.Duration
 
-	// Handler for when authenticacion fails
-	UnauthorizedHandler http.Handler
+	// duration for refresh tokens, separate from CredentialsDuration since
+	// refresh tokens typically outlive the access credential they mint
+	RefreshDuration time.Duration
+
+	// Handler for when authenticacion fails. Defaults to DefaultUnauthorizedHandler.
+	UnauthorizedHandler func(w http.ResponseWriter, r *http.Request, err error)
 
 	// Handler for when access is denied (role verification failed)
 	ForbiddenHandler http.Handler
@@ -51,27 +69,6 @@ type AuthOptions struct {
 	CredentialsExtractor func(r *http.Request) (interface{}, error)
 }
 
-type AuthError struct {
-	Code    int
-	Message string
-	Err     error
-}
-
-func (e AuthError) Error() string {
-	return e.Message
-}
-
-func (e AuthError) Unwrap() error {
-	return e.Err
-}
-
-var (
-	ErrInvalidCredentials = AuthError{Code: http.StatusUnauthorized, Message: "invalid credentials"}
-	ErrExpiredCredentials = AuthError{Code: http.StatusUnauthorized, Message: "expired credentials"}
-	ErrMissingCredentials = AuthError{Code: http.StatusUnauthorized, Message: "crendentials not provided"}
-	ErrAccessDenied       = AuthError{Code: http.StatusForbidden, Message: "access denied"}
-)
-
 // UserFromContext extract a authenticated user from context
 func UserFromContext(ctx context.Context) (*AuthenticatedUser, bool) {
 	user, ok := ctx.Value(userContextKey).(*AuthenticatedUser)
@@ -0,0 +1,259 @@
+// chain.go
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ChainChild is one scheme composed into a ChainAuthenticator.
+type ChainChild struct {
+	// Name identifies this child for WithPreferredScheme, e.g. "bearer".
+	Name string
+	// Scheme and Realm are used to build this child's WWW-Authenticate
+	// challenge, e.g. Scheme: "Bearer", Realm: "api".
+	Scheme string
+	Realm  string
+
+	Authenticator Authenticator
+}
+
+// ChainAuthenticator composes several Authenticator schemes (Basic, Bearer
+// JWT, cookie session, ...) and tries each in request order on
+// Authenticate, short-circuiting on the first success. When every child
+// fails, its Middleware responds with a WWW-Authenticate header listing all
+// child challenges so the client can negotiate, as described in RFC 7235.
+type ChainAuthenticator struct {
+	children []ChainChild
+	opts     AuthOptions
+	access   AccessController
+}
+
+// NewChainAuthenticator builds a ChainAuthenticator trying children in the
+// given order.
+func NewChainAuthenticator(opts AuthOptions, children ...ChainChild) *ChainAuthenticator {
+	if opts.UnauthorizedHandler == nil {
+		opts.UnauthorizedHandler = DefaultUnauthorizedHandler
+	}
+	return &ChainAuthenticator{children: children, opts: opts}
+}
+
+// SetAccessController wires an AccessController for MiddlewareWithAccess.
+func (c *ChainAuthenticator) SetAccessController(controller AccessController) {
+	c.access = controller
+}
+
+type preferredSchemeKey struct{}
+
+// WithPreferredScheme marks which child (by ChainChild.Name) GenerateCredentials
+// and WriteCredentials should delegate to.
+func WithPreferredScheme(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, preferredSchemeKey{}, name)
+}
+
+// PreferredSchemeFromContext returns the scheme name set by WithPreferredScheme, if any.
+func PreferredSchemeFromContext(ctx context.Context) (string, bool) {
+	name, ok := ctx.Value(preferredSchemeKey{}).(string)
+	return name, ok
+}
+
+func (c *ChainAuthenticator) child(name string) (ChainChild, bool) {
+	for _, child := range c.children {
+		if child.Name == name {
+			return child, true
+		}
+	}
+	return ChainChild{}, false
+}
+
+// Authenticate tries each child Authenticator in order, returning the first
+// success. If all fail, it returns the last child's error.
+func (c *ChainAuthenticator) Authenticate(ctx context.Context, r *http.Request) (*AuthenticatedUser, error) {
+	var lastErr error = ErrMissingCredentials
+
+	for _, child := range c.children {
+		user, err := child.Authenticator.Authenticate(ctx, r)
+		if err == nil {
+			return user, nil
+		}
+		lastErr = err
+	}
+
+	return nil, lastErr
+}
+
+// GenerateCredentials always delegates to the first child: the Authenticator
+// interface gives this method no context.Context, so it cannot honor
+// WithPreferredScheme. Use GenerateCredentialsForScheme when the caller has a
+// ctx carrying a preferred scheme.
+func (c *ChainAuthenticator) GenerateCredentials(user *AuthenticatedUser) (interface{}, error) {
+	return c.firstChild().Authenticator.GenerateCredentials(user)
+}
+
+// GenerateCredentialsForScheme generates credentials via the named child.
+func (c *ChainAuthenticator) GenerateCredentialsForScheme(ctx context.Context, user *AuthenticatedUser) (interface{}, error) {
+	child, err := c.resolveChild(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return child.Authenticator.GenerateCredentials(user)
+}
+
+func (c *ChainAuthenticator) resolveChild(ctx context.Context) (ChainChild, error) {
+	if name, ok := PreferredSchemeFromContext(ctx); ok {
+		child, ok := c.child(name)
+		if !ok {
+			return ChainChild{}, fmt.Errorf("auth: unknown chain scheme %q", name)
+		}
+		return child, nil
+	}
+	return c.firstChild(), nil
+}
+
+func (c *ChainAuthenticator) firstChild() ChainChild {
+	if len(c.children) == 0 {
+		return ChainChild{}
+	}
+	return c.children[0]
+}
+
+// ValidateCredentials tries creds against each child, returning the first success.
+func (c *ChainAuthenticator) ValidateCredentials(creds interface{}) (*AuthenticatedUser, error) {
+	var lastErr error = ErrInvalidCredentials
+	for _, child := range c.children {
+		user, err := child.Authenticator.ValidateCredentials(creds)
+		if err == nil {
+			return user, nil
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+// WriteCredentials always delegates to the first child: the Authenticator
+// interface gives this method no context.Context, so it cannot honor
+// WithPreferredScheme. Use WriteCredentialsForScheme when the caller has a
+// ctx carrying a preferred scheme.
+func (c *ChainAuthenticator) WriteCredentials(w http.ResponseWriter, creds interface{}) error {
+	return c.firstChild().Authenticator.WriteCredentials(w, creds)
+}
+
+// WriteCredentialsForScheme writes creds via the child named by
+// WithPreferredScheme in ctx, or the first child if none was set.
+func (c *ChainAuthenticator) WriteCredentialsForScheme(ctx context.Context, w http.ResponseWriter, creds interface{}) error {
+	child, err := c.resolveChild(ctx)
+	if err != nil {
+		return err
+	}
+	return child.Authenticator.WriteCredentials(w, creds)
+}
+
+// RefreshCredentials delegates to the child named by WithPreferredScheme, or
+// the first child if none was set.
+func (c *ChainAuthenticator) RefreshCredentials(ctx context.Context, refreshToken interface{}) (*AuthenticatedUser, interface{}, error) {
+	child, err := c.resolveChild(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return child.Authenticator.RefreshCredentials(ctx, refreshToken)
+}
+
+// RevokeCredentials delegates to the child named by WithPreferredScheme, or
+// the first child if none was set.
+func (c *ChainAuthenticator) RevokeCredentials(ctx context.Context, creds interface{}) error {
+	child, err := c.resolveChild(ctx)
+	if err != nil {
+		return err
+	}
+	return child.Authenticator.RevokeCredentials(ctx, creds)
+}
+
+// Middleware authenticates via Authenticate and, on total failure, writes a
+// multi-scheme WWW-Authenticate header before invoking UnauthorizedHandler.
+func (c *ChainAuthenticator) Middleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user, err := c.Authenticate(r.Context(), r)
+			if err != nil {
+				c.writeWWWAuthenticate(w)
+				c.opts.UnauthorizedHandler(w, r, err)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(SetUserContext(r.Context(), user)))
+		})
+	}
+}
+
+// writeWWWAuthenticate sets one WWW-Authenticate header value per child that
+// has a Scheme, as allowed by RFC 7235 section 4.1; children without a Scheme
+// are left out.
+func (c *ChainAuthenticator) writeWWWAuthenticate(w http.ResponseWriter) {
+	for _, child := range c.children {
+		if child.Scheme == "" {
+			continue
+		}
+		w.Header().Add("WWW-Authenticate", chainChallenge(child))
+	}
+}
+
+func chainChallenge(child ChainChild) string {
+	if child.Realm == "" {
+		return child.Scheme
+	}
+	return fmt.Sprintf(`%s realm=%q`, child.Scheme, child.Realm)
+}
+
+// MiddlewareWithRoles authenticates via Authenticate, then requires the user
+// to have at least one of roles.
+func (c *ChainAuthenticator) MiddlewareWithRoles(roles ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return c.Middleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user, _ := UserFromContext(r.Context())
+			if user == nil || !chainHasAnyRole(user.Roles, roles) {
+				c.forbidden(w, r)
+				return
+			}
+			next.ServeHTTP(w, r)
+		}))
+	}
+}
+
+// MiddlewareWithAccess authenticates via Authenticate, then authorizes
+// against access via the AccessController set with SetAccessController.
+func (c *ChainAuthenticator) MiddlewareWithAccess(access ...Access) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return c.Middleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if c.access == nil {
+				c.forbidden(w, r)
+				return
+			}
+			ctx, err := c.access.Authorized(r.Context(), access...)
+			if err != nil {
+				c.opts.UnauthorizedHandler(w, r, err)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(ctx))
+		}))
+	}
+}
+
+func (c *ChainAuthenticator) forbidden(w http.ResponseWriter, r *http.Request) {
+	if c.opts.ForbiddenHandler != nil {
+		c.opts.ForbiddenHandler.ServeHTTP(w, r)
+		return
+	}
+	c.opts.UnauthorizedHandler(w, r, ErrAccessDenied)
+}
+
+func chainHasAnyRole(have, want []string) bool {
+	for _, w := range want {
+		for _, h := range have {
+			if strings.EqualFold(h, w) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,39 @@
+// credential.go
+package auth
+
+import "context"
+
+// CredentialAuthenticator verifies a username/password pair against a
+// credential store. It is intentionally decoupled from Authenticator: an
+// Authenticator decides how a session is issued and validated (JWT, cookie,
+// etc.), while a CredentialAuthenticator only decides whether a given
+// username/password pair is valid and who it belongs to.
+type CredentialAuthenticator interface {
+	// AuthenticateUser verifies username/password and returns the matching user.
+	// Implementations should return ErrInvalidCredentials when the password does
+	// not match and ErrMissingCredentials when either field is empty.
+	AuthenticateUser(ctx context.Context, username, password string) (*AuthenticatedUser, error)
+}
+
+// LoginWithCredentials verifies username/password against creds and, on
+// success, issues session credentials via auth. It is glue for an
+// Authenticator's /login handler: password
+// verification and credential storage are handled by creds, while auth keeps
+// deciding the shape of the session it hands back.
+func LoginWithCredentials(ctx context.Context, creds CredentialAuthenticator, auth Authenticator, username, password string) (*AuthenticatedUser, interface{}, error) {
+	if username == "" || password == "" {
+		return nil, nil, ErrMissingCredentials
+	}
+
+	user, err := creds.AuthenticateUser(ctx, username, password)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	token, err := auth.GenerateCredentials(user)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return user, token, nil
+}
@@ -0,0 +1,64 @@
+// credential_htpasswd_test.go
+package auth
+
+import "testing"
+
+func TestVerifyHtpasswd(t *testing.T) {
+	tests := []struct {
+		name     string
+		encoded  string
+		password string
+		want     bool
+	}{
+		{
+			name:     "bcrypt match",
+			encoded:  "$2a$10$1rlwFIMghSwKYLwLvatERu31FYsjI5p7Cq1CRevjwq30yO4LrTsJ2",
+			password: "password",
+			want:     true,
+		},
+		{
+			name:     "bcrypt mismatch",
+			encoded:  "$2a$10$1rlwFIMghSwKYLwLvatERu31FYsjI5p7Cq1CRevjwq30yO4LrTsJ2",
+			password: "wrong-password",
+			want:     false,
+		},
+		{
+			name:     "sha match",
+			encoded:  "{SHA}5en6G6MezRroT3XKqkdPOmY/BfQ=",
+			password: "secret",
+			want:     true,
+		},
+		{
+			name:     "sha mismatch",
+			encoded:  "{SHA}5en6G6MezRroT3XKqkdPOmY/BfQ=",
+			password: "wrong-secret",
+			want:     false,
+		},
+		{
+			name:     "apr1 match",
+			encoded:  "$apr1$r31.4iyL$ksaUUwS9e8HvYZxGtUmIn0",
+			password: "myPassword",
+			want:     true,
+		},
+		{
+			name:     "apr1 mismatch",
+			encoded:  "$apr1$r31.4iyL$ksaUUwS9e8HvYZxGtUmIn0",
+			password: "wrongPassword",
+			want:     false,
+		},
+		{
+			name:     "unsupported crypt format",
+			encoded:  "abFOFuFhSp5X2",
+			password: "anything",
+			want:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := verifyHtpasswd(tt.encoded, tt.password); got != tt.want {
+				t.Errorf("verifyHtpasswd(%q, %q) = %v, want %v", tt.encoded, tt.password, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+// credential_ldap.go
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+// LDAPAuthenticator is a CredentialAuthenticator that verifies credentials by
+// binding to an LDAP/Active Directory server.
+type LDAPAuthenticator struct {
+	// Addr is the LDAP server address, e.g. "ldap.example.com:389".
+	Addr string
+
+	// UserDNTemplate builds the bind DN for a username, e.g.
+	// "uid=%s,ou=people,dc=example,dc=com".
+	UserDNTemplate string
+
+	// Dial defaults to ldap.DialURL against Addr but can be overridden in tests.
+	Dial func(addr string) (*ldap.Conn, error)
+}
+
+// NewLDAPAuthenticator creates an LDAPAuthenticator bound against addr, using
+// userDNTemplate to build each user's bind DN.
+func NewLDAPAuthenticator(addr, userDNTemplate string) *LDAPAuthenticator {
+	return &LDAPAuthenticator{
+		Addr:           addr,
+		UserDNTemplate: userDNTemplate,
+		Dial: func(addr string) (*ldap.Conn, error) {
+			return ldap.DialURL("ldap://" + addr)
+		},
+	}
+}
+
+// AuthenticateUser implements CredentialAuthenticator by performing an LDAP
+// simple bind with the user's DN and password.
+func (l *LDAPAuthenticator) AuthenticateUser(ctx context.Context, username, password string) (*AuthenticatedUser, error) {
+	if username == "" || password == "" {
+		return nil, ErrMissingCredentials
+	}
+
+	conn, err := l.Dial(l.Addr)
+	if err != nil {
+		return nil, AuthError{Code: CodeInvalidCredentials, StatusCode: http.StatusServiceUnavailable, Message: "ldap server unavailable", Err: err}
+	}
+	defer conn.Close()
+
+	dn := fmt.Sprintf(l.UserDNTemplate, username)
+	if err := conn.Bind(dn, password); err != nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	return &AuthenticatedUser{ID: dn, Username: username}, nil
+}
@@ -0,0 +1,41 @@
+// credential_static.go
+package auth
+
+import "context"
+
+// StaticCredentialAuthenticator is an in-memory CredentialAuthenticator
+// backed by a fixed username -> *AuthenticatedUser map and a matching
+// username -> password map. It performs no hashing and is intended for
+// tests and local development, not production credential storage.
+type StaticCredentialAuthenticator struct {
+	users     map[string]*AuthenticatedUser
+	passwords map[string]string
+}
+
+// NewStaticCredentialAuthenticator creates a StaticCredentialAuthenticator
+// from the given users, keyed by username, and their plaintext passwords.
+func NewStaticCredentialAuthenticator(users map[string]*AuthenticatedUser, passwords map[string]string) *StaticCredentialAuthenticator {
+	return &StaticCredentialAuthenticator{
+		users:     users,
+		passwords: passwords,
+	}
+}
+
+// AuthenticateUser implements CredentialAuthenticator.
+func (s *StaticCredentialAuthenticator) AuthenticateUser(ctx context.Context, username, password string) (*AuthenticatedUser, error) {
+	if username == "" || password == "" {
+		return nil, ErrMissingCredentials
+	}
+
+	want, ok := s.passwords[username]
+	if !ok || want != password {
+		return nil, ErrInvalidCredentials
+	}
+
+	user, ok := s.users[username]
+	if !ok {
+		return nil, ErrInvalidCredentials
+	}
+
+	return user, nil
+}
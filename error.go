@@ -0,0 +1,124 @@
+// error.go
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+// Machine-readable AuthError codes. Callers should switch on these (or use
+// errors.Is against the sentinel errors below) instead of matching on
+// Message, which is free to change wording.
+const (
+	CodeInvalidCredentials = "AUTH-INVALID-CREDENTIALS"
+	CodeExpiredCredentials = "AUTH-EXPIRED"
+	CodeMissingCredentials = "AUTH-MISSING-CREDENTIALS"
+	CodeAccessDenied       = "AUTH-ACCESS-DENIED"
+	CodeInvalidToken       = "AUTH-INVALID-TOKEN"
+	CodeBadHeader          = "AUTH-BAD-HEADER"
+	CodeInvalidIssuer      = "AUTH-INVALID-ISSUER"
+	CodeInvalidClientIP    = "AUTH-INVALID-CLIENT-IP"
+)
+
+// AuthError is a structured error returned by Authenticator and
+// CredentialAuthenticator implementations. Code is a stable, machine-readable
+// identifier (see the Code* constants); StatusCode is the HTTP status the
+// failure should be reported as. Response is populated when the failure was
+// observed from an upstream IdP (e.g. a failed OIDC introspection call), and
+// Details carries extra key/value pairs for audit logging.
+type AuthError struct {
+	Code       string
+	StatusCode int
+	Message    string
+	Err        error
+
+	// Response is the upstream HTTP response that produced this error, if any
+	// (e.g. a non-2xx response from an OIDC introspection endpoint).
+	Response *http.Response
+
+	// Details carries additional structured context for audit logging, such
+	// as the client IP, request ID, or the claim that failed validation.
+	Details map[string]any
+}
+
+func (e AuthError) Error() string {
+	return e.Message
+}
+
+func (e AuthError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is an AuthError with the same Code, so that
+// errors.Is(err, ErrExpiredCredentials) works regardless of the Err/Details/
+// Response carried on err.
+func (e AuthError) Is(target error) bool {
+	t, ok := target.(AuthError)
+	if !ok {
+		return false
+	}
+	return e.Code == t.Code
+}
+
+// WithDetails returns a copy of e with Details merged in.
+func (e AuthError) WithDetails(details map[string]any) AuthError {
+	merged := make(map[string]any, len(e.Details)+len(details))
+	for k, v := range e.Details {
+		merged[k] = v
+	}
+	for k, v := range details {
+		merged[k] = v
+	}
+	e.Details = merged
+	return e
+}
+
+// WithResponse returns a copy of e with an upstream HTTP response attached.
+func (e AuthError) WithResponse(resp *http.Response) AuthError {
+	e.Response = resp
+	return e
+}
+
+var (
+	ErrInvalidCredentials = AuthError{Code: CodeInvalidCredentials, StatusCode: http.StatusUnauthorized, Message: "invalid credentials"}
+	ErrExpiredCredentials = AuthError{Code: CodeExpiredCredentials, StatusCode: http.StatusUnauthorized, Message: "expired credentials"}
+	ErrMissingCredentials = AuthError{Code: CodeMissingCredentials, StatusCode: http.StatusUnauthorized, Message: "crendentials not provided"}
+	ErrAccessDenied       = AuthError{Code: CodeAccessDenied, StatusCode: http.StatusForbidden, Message: "access denied"}
+	ErrInvalidToken       = AuthError{Code: CodeInvalidToken, StatusCode: http.StatusUnauthorized, Message: "invalid token"}
+	ErrBadHeader          = AuthError{Code: CodeBadHeader, StatusCode: http.StatusUnauthorized, Message: "malformed authorization header"}
+	ErrInvalidIssuer      = AuthError{Code: CodeInvalidIssuer, StatusCode: http.StatusUnauthorized, Message: "invalid issuer"}
+	ErrInvalidClientIP    = AuthError{Code: CodeInvalidClientIP, StatusCode: http.StatusUnauthorized, Message: "invalid client ip"}
+)
+
+// problemDetails is an application/problem+json body (RFC 7807) for an AuthError.
+type problemDetails struct {
+	Title   string         `json:"title"`
+	Status  int            `json:"status"`
+	Code    string         `json:"code"`
+	Detail  string         `json:"detail,omitempty"`
+	Details map[string]any `json:"details,omitempty"`
+}
+
+// DefaultUnauthorizedHandler writes err as an application/problem+json body.
+// If err is (or wraps) an AuthError, its Code, StatusCode and Details are
+// used; otherwise it falls back to ErrInvalidCredentials.
+func DefaultUnauthorizedHandler(w http.ResponseWriter, r *http.Request, err error) {
+	authErr := ErrInvalidCredentials
+	errors.As(err, &authErr)
+
+	if authErr.StatusCode < 100 {
+		authErr.StatusCode = http.StatusInternalServerError
+	}
+
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(authErr.StatusCode)
+
+	_ = json.NewEncoder(w).Encode(problemDetails{
+		Title:   http.StatusText(authErr.StatusCode),
+		Status:  authErr.StatusCode,
+		Code:    authErr.Code,
+		Detail:  authErr.Message,
+		Details: authErr.Details,
+	})
+}
@@ -0,0 +1,331 @@
+// authenticator.go
+package oidc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/josevitorrodriguess/goauth"
+	"golang.org/x/oauth2"
+)
+
+// Authenticator implements auth.Authenticator against an OIDC provider: it
+// drives the authorization-code-with-PKCE flow for login, verifies bearer
+// tokens via JWKS (falling back to RFC 7662 introspection for opaque
+// tokens), and populates AuthenticatedUser.Roles from a configurable claim.
+type Authenticator struct {
+	cfg    Config
+	opts   auth.AuthOptions
+	oauth2 oauth2.Config
+	jwks   *jwksCache
+	hc     *http.Client
+	access auth.AccessController
+}
+
+// NewAuthenticator builds an Authenticator from cfg. opts.UnauthorizedHandler
+// defaults to auth.DefaultUnauthorizedHandler when nil.
+func NewAuthenticator(cfg Config, opts auth.AuthOptions) *Authenticator {
+	if opts.UnauthorizedHandler == nil {
+		opts.UnauthorizedHandler = auth.DefaultUnauthorizedHandler
+	}
+
+	return &Authenticator{
+		cfg:  cfg,
+		opts: opts,
+		oauth2: oauth2.Config{
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			RedirectURL:  cfg.RedirectURL,
+			Scopes:       cfg.scopes(),
+			Endpoint: oauth2.Endpoint{
+				AuthURL:  cfg.AuthURL,
+				TokenURL: cfg.TokenURL,
+			},
+		},
+		jwks: newJWKSCache(cfg.JWKSURL, cfg.jwksCacheTTL()),
+		hc:   http.DefaultClient,
+	}
+}
+
+// SetAccessController wires an auth.AccessController into this Authenticator
+// so MiddlewareWithAccess can be used; it is optional and only needed by
+// callers that authorize per-resource rather than per-role.
+func (a *Authenticator) SetAccessController(controller auth.AccessController) {
+	a.access = controller
+}
+
+func (a *Authenticator) httpClient() *http.Client {
+	if a.hc != nil {
+		return a.hc
+	}
+	return http.DefaultClient
+}
+
+// AuthCodeURL builds the provider's authorization URL for state and a fresh
+// PKCE challenge; callers must persist pkce.Verifier (e.g. in a server-side
+// session) to complete the exchange in Callback.
+func (a *Authenticator) AuthCodeURL(state string, pkce PKCE) string {
+	return a.oauth2.AuthCodeURL(state,
+		oauth2.SetAuthURLParam("code_challenge", pkce.Challenge),
+		oauth2.SetAuthURLParam("code_challenge_method", "S256"),
+	)
+}
+
+// Callback exchanges an authorization code (plus its PKCE verifier) for
+// tokens and returns the authenticated user.
+func (a *Authenticator) Callback(ctx context.Context, code string, pkce PKCE) (*AuthenticatedUser, error) {
+	token, err := a.oauth2.Exchange(ctx, code, oauth2.SetAuthURLParam("code_verifier", pkce.Verifier))
+	if err != nil {
+		return nil, auth.AuthError{Code: auth.CodeInvalidToken, StatusCode: http.StatusUnauthorized, Message: "token exchange failed", Err: err}
+	}
+
+	idToken, _ := token.Extra("id_token").(string)
+	if idToken == "" {
+		return nil, auth.ErrInvalidToken
+	}
+
+	return a.userFromToken(ctx, idToken)
+}
+
+// AuthenticatedUser is an alias kept local for readability; it is exactly
+// auth.AuthenticatedUser.
+type AuthenticatedUser = auth.AuthenticatedUser
+
+// Authenticate implements auth.Authenticator by validating the bearer token
+// in the Authorization header.
+func (a *Authenticator) Authenticate(ctx context.Context, r *http.Request) (*AuthenticatedUser, error) {
+	header := r.Header.Get("Authorization")
+	if header == "" {
+		return nil, auth.ErrMissingCredentials
+	}
+
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return nil, auth.ErrBadHeader
+	}
+
+	return a.userFromToken(ctx, token)
+}
+
+// userFromToken verifies token as a JWT via JWKS; if parsing or verifying it
+// as a JWT fails for any reason and introspection is configured, it falls
+// back to RFC 7662.
+func (a *Authenticator) userFromToken(ctx context.Context, token string) (*AuthenticatedUser, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, a.jwks.keyfunc(ctx), jwt.WithValidMethods([]string{"RS256"}))
+	if err != nil {
+		if a.cfg.IntrospectionURL == "" {
+			return nil, auth.AuthError{Code: auth.CodeInvalidToken, StatusCode: http.StatusUnauthorized, Message: "invalid token", Err: err}
+		}
+
+		introspected, err := a.introspect(ctx, token)
+		if err != nil {
+			return nil, err
+		}
+		// Introspection already asserted active=true and the claims came
+		// straight from the IdP, so iss/aud (frequently omitted from RFC 7662
+		// responses) are not re-checked here.
+		return a.userFromClaims(introspected), nil
+	}
+
+	if err := a.validateClaims(claims); err != nil {
+		return nil, err
+	}
+
+	return a.userFromClaims(claims), nil
+}
+
+func (a *Authenticator) validateClaims(claims jwt.MapClaims) error {
+	iss, _ := claims["iss"].(string)
+	if iss != a.cfg.IssuerURL {
+		return errInvalidIssuer
+	}
+
+	if exp, err := claims.GetExpirationTime(); err == nil && exp != nil {
+		if time.Now().After(exp.Time) {
+			return errExpiredCredentials
+		}
+	}
+
+	if nbf, err := claims.GetNotBefore(); err == nil && nbf != nil {
+		if time.Now().Before(nbf.Time) {
+			return auth.ErrInvalidToken
+		}
+	}
+
+	aud, _ := claims.GetAudience()
+	azp, _ := claims["azp"].(string)
+	if !containsString(aud, a.cfg.ClientID) && azp != a.cfg.ClientID {
+		return auth.AuthError{Code: auth.CodeInvalidToken, StatusCode: http.StatusUnauthorized, Message: "token not intended for this client"}
+	}
+
+	return nil
+}
+
+func (a *Authenticator) userFromClaims(claims jwt.MapClaims) *AuthenticatedUser {
+	sub, _ := claims["sub"].(string)
+	username, _ := claims["preferred_username"].(string)
+	if username == "" {
+		username = sub
+	}
+
+	return &AuthenticatedUser{
+		ID:       sub,
+		Username: username,
+		Roles:    claimPath(claims, a.cfg.RolesClaimPath),
+		Metadata: map[string]interface{}{"claims": map[string]interface{}(claims)},
+	}
+}
+
+func containsString(haystack []string, needle string) bool {
+	for _, s := range haystack {
+		if s == needle {
+			return true
+		}
+	}
+	return false
+}
+
+// GenerateCredentials is not used for login: OIDC credentials are minted by
+// the provider during Callback, not by this package.
+func (a *Authenticator) GenerateCredentials(user *AuthenticatedUser) (interface{}, error) {
+	return nil, fmt.Errorf("oidc: credentials are issued by the provider; use Callback instead of GenerateCredentials")
+}
+
+// ValidateCredentials treats creds as a bearer token string and validates it
+// the same way Authenticate does.
+func (a *Authenticator) ValidateCredentials(creds interface{}) (*AuthenticatedUser, error) {
+	token, ok := creds.(string)
+	if !ok || token == "" {
+		return nil, auth.ErrMissingCredentials
+	}
+	return a.userFromToken(context.Background(), token)
+}
+
+// WriteCredentials writes the access token as a Bearer Authorization header.
+// creds is either the token string or an auth.CredentialPair (as returned by
+// RefreshCredentials) whose Access is that string.
+func (a *Authenticator) WriteCredentials(w http.ResponseWriter, creds interface{}) error {
+	if pair, ok := creds.(auth.CredentialPair); ok {
+		creds = pair.Access
+	}
+	token, ok := creds.(string)
+	if !ok {
+		return auth.ErrMissingCredentials
+	}
+	w.Header().Set("Authorization", "Bearer "+token)
+	return nil
+}
+
+// RefreshCredentials exchanges a refresh token with the provider's token
+// endpoint for a new access (and possibly refresh) token.
+func (a *Authenticator) RefreshCredentials(ctx context.Context, refreshToken interface{}) (*AuthenticatedUser, interface{}, error) {
+	rt, ok := refreshToken.(string)
+	if !ok || rt == "" {
+		return nil, nil, auth.ErrMissingCredentials
+	}
+
+	src := a.oauth2.TokenSource(ctx, &oauth2.Token{RefreshToken: rt})
+	token, err := src.Token()
+	if err != nil {
+		return nil, nil, auth.AuthError{Code: auth.CodeExpiredCredentials, StatusCode: http.StatusUnauthorized, Message: "refresh failed", Err: err}
+	}
+
+	idToken, _ := token.Extra("id_token").(string)
+	if idToken == "" {
+		idToken = token.AccessToken
+	}
+
+	user, err := a.userFromToken(ctx, idToken)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pair := auth.CredentialPair{
+		Access:          token.AccessToken,
+		Refresh:         token.RefreshToken,
+		AccessExpiresAt: token.Expiry,
+	}
+	if a.opts.RefreshDuration > 0 {
+		pair.RefreshExpiresAt = time.Now().Add(a.opts.RefreshDuration)
+	}
+
+	return user, pair, nil
+}
+
+// RevokeCredentials is a no-op placeholder: providers that support RFC 7009
+// revocation should call their revocation endpoint here.
+func (a *Authenticator) RevokeCredentials(ctx context.Context, creds interface{}) error {
+	return nil
+}
+
+// Middleware returns an http middleware enforcing Authenticate.
+func (a *Authenticator) Middleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user, err := a.Authenticate(r.Context(), r)
+			if err != nil {
+				a.opts.UnauthorizedHandler(w, r, err)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(auth.SetUserContext(r.Context(), user)))
+		})
+	}
+}
+
+// MiddlewareWithRoles returns a middleware that additionally requires the
+// authenticated user to have at least one of roles.
+func (a *Authenticator) MiddlewareWithRoles(roles ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return a.Middleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user, _ := auth.UserFromContext(r.Context())
+			if user == nil || !hasAnyRole(user.Roles, roles) {
+				if a.opts.ForbiddenHandler != nil {
+					a.opts.ForbiddenHandler.ServeHTTP(w, r)
+					return
+				}
+				a.opts.UnauthorizedHandler(w, r, auth.ErrAccessDenied)
+				return
+			}
+			next.ServeHTTP(w, r)
+		}))
+	}
+}
+
+// MiddlewareWithAccess returns a middleware that authorizes the request
+// against access via the AccessController set with SetAccessController.
+func (a *Authenticator) MiddlewareWithAccess(access ...auth.Access) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return a.Middleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if a.access == nil {
+				a.opts.UnauthorizedHandler(w, r, auth.ErrAccessDenied)
+				return
+			}
+			ctx, err := a.access.Authorized(r.Context(), access...)
+			if err != nil {
+				if a.opts.ForbiddenHandler != nil {
+					a.opts.ForbiddenHandler.ServeHTTP(w, r)
+					return
+				}
+				a.opts.UnauthorizedHandler(w, r, err)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(ctx))
+		}))
+	}
+}
+
+func hasAnyRole(have, want []string) bool {
+	for _, w := range want {
+		for _, h := range have {
+			if h == w {
+				return true
+			}
+		}
+	}
+	return false
+}
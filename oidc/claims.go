@@ -0,0 +1,40 @@
+// claims.go
+package oidc
+
+import "strings"
+
+// claimPath walks claims following path segments separated by ".", returning
+// a []string at the end (either a []interface{} of strings or a single
+// string, to tolerate providers that return a lone role as a scalar).
+func claimPath(claims map[string]interface{}, path string) []string {
+	if path == "" {
+		return nil
+	}
+
+	var cur interface{} = claims
+	for _, segment := range strings.Split(path, ".") {
+		m, ok := cur.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		cur, ok = m[segment]
+		if !ok {
+			return nil
+		}
+	}
+
+	switch v := cur.(type) {
+	case []interface{}:
+		roles := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				roles = append(roles, s)
+			}
+		}
+		return roles
+	case string:
+		return []string{v}
+	default:
+		return nil
+	}
+}
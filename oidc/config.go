@@ -0,0 +1,58 @@
+// config.go
+package oidc
+
+import "time"
+
+// Config holds everything needed to talk to an OIDC provider: endpoint
+// discovery is not performed automatically, so all URLs are supplied
+// explicitly (they are typically read once from the provider's
+// /.well-known/openid-configuration document at startup).
+type Config struct {
+	// IssuerURL is the expected `iss` claim on tokens issued by this provider.
+	IssuerURL string
+
+	// ClientID and ClientSecret identify this application to the provider.
+	ClientID     string
+	ClientSecret string
+
+	// RedirectURL is where the provider sends the user back after login.
+	RedirectURL string
+
+	// AuthURL, TokenURL and JWKSURL are taken from provider discovery.
+	AuthURL  string
+	TokenURL string
+	JWKSURL  string
+
+	// IntrospectionURL enables RFC 7662 introspection for opaque access
+	// tokens. Leave empty when the provider only issues JWTs.
+	IntrospectionURL string
+
+	// Scopes requested during the authorization-code flow. "openid" is added
+	// automatically if missing.
+	Scopes []string
+
+	// RolesClaimPath locates the roles in the token claims, using "." to
+	// descend into nested objects, e.g. "realm_access.roles" for Keycloak or
+	// "groups" for a generic provider.
+	RolesClaimPath string
+
+	// JWKSCacheTTL controls how long a fetched JWKS is cached before being
+	// refetched. Defaults to 10 minutes.
+	JWKSCacheTTL time.Duration
+}
+
+func (c Config) scopes() []string {
+	for _, s := range c.Scopes {
+		if s == "openid" {
+			return c.Scopes
+		}
+	}
+	return append([]string{"openid"}, c.Scopes...)
+}
+
+func (c Config) jwksCacheTTL() time.Duration {
+	if c.JWKSCacheTTL <= 0 {
+		return 10 * time.Minute
+	}
+	return c.JWKSCacheTTL
+}
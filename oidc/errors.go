@@ -0,0 +1,28 @@
+// errors.go
+package oidc
+
+import (
+	"net/http"
+
+	"github.com/josevitorrodriguess/goauth"
+)
+
+const errCodeIntrospectionFailed = "AUTH-OIDC-INTROSPECTION-FAILED"
+
+var (
+	errExpiredCredentials = auth.ErrExpiredCredentials
+	errInvalidIssuer      = auth.ErrInvalidIssuer
+)
+
+// authError builds an auth.AuthError carrying the upstream HTTP response that
+// produced it, for failures observed talking to the IdP's introspection
+// endpoint. JWKS fetch failures are reported as plain errors, without a
+// Response.
+func authError(code, message string, resp *http.Response) auth.AuthError {
+	return auth.AuthError{
+		Code:       code,
+		StatusCode: http.StatusUnauthorized,
+		Message:    message,
+		Response:   resp,
+	}
+}
@@ -0,0 +1,49 @@
+// introspect.go
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// introspect performs RFC 7662 token introspection for opaque access tokens
+// that cannot be verified locally via JWKS.
+func (a *Authenticator) introspect(ctx context.Context, token string) (map[string]interface{}, error) {
+	form := url.Values{
+		"token":           {token},
+		"token_type_hint": {"access_token"},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.cfg.IntrospectionURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("oidc: building introspection request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(a.cfg.ClientID, a.cfg.ClientSecret)
+
+	resp, err := a.httpClient().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("oidc: calling introspection endpoint: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, authError(errCodeIntrospectionFailed, "introspection endpoint error", resp)
+	}
+
+	var claims map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&claims); err != nil {
+		return nil, fmt.Errorf("oidc: decoding introspection response: %w", err)
+	}
+
+	active, _ := claims["active"].(bool)
+	if !active {
+		return nil, errExpiredCredentials
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,30 @@
+// pkce.go
+package oidc
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+)
+
+// PKCE is a single authorization-code-with-PKCE exchange's verifier/challenge
+// pair. Verifier must be kept server-side (e.g. in a short-lived session)
+// between AuthCodeURL and the callback that exchanges the code.
+type PKCE struct {
+	Verifier  string
+	Challenge string
+}
+
+// NewPKCE generates a fresh S256 PKCE verifier/challenge pair.
+func NewPKCE() (PKCE, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return PKCE{}, err
+	}
+	verifier := base64.RawURLEncoding.EncodeToString(raw)
+
+	sum := sha256.Sum256([]byte(verifier))
+	challenge := base64.RawURLEncoding.EncodeToString(sum[:])
+
+	return PKCE{Verifier: verifier, Challenge: challenge}, nil
+}
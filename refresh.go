@@ -0,0 +1,57 @@
+// refresh.go
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// CredentialPair is the access+refresh credential shape returned by
+// Authenticator implementations that support refresh tokens. It is handed
+// back as the interface{} result of GenerateCredentials so the rest of the
+// interface stays credential-type agnostic.
+type CredentialPair struct {
+	// Access is the short-lived credential (token, cookie value, etc.).
+	Access interface{}
+	// Refresh is the long-lived credential used to mint a new Access value.
+	Refresh interface{}
+
+	AccessExpiresAt  time.Time
+	RefreshExpiresAt time.Time
+}
+
+// refreshRequest is the expected JSON body for RefreshHandler.
+type refreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// RefreshHandler returns a ready-to-mount http.Handler that reads a refresh
+// token from a JSON body, exchanges it via auth.RefreshCredentials, and
+// writes the resulting credentials with auth.WriteCredentials. On failure it
+// reports the error through unauthorized, defaulting to
+// DefaultUnauthorizedHandler when nil.
+func RefreshHandler(auth Authenticator, unauthorized func(w http.ResponseWriter, r *http.Request, err error)) http.Handler {
+	if unauthorized == nil {
+		unauthorized = DefaultUnauthorizedHandler
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body refreshRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.RefreshToken == "" {
+			unauthorized(w, r, ErrMissingCredentials)
+			return
+		}
+
+		_, creds, err := auth.RefreshCredentials(r.Context(), body.RefreshToken)
+		if err != nil {
+			unauthorized(w, r, err)
+			return
+		}
+
+		if err := auth.WriteCredentials(w, creds); err != nil {
+			unauthorized(w, r, err)
+			return
+		}
+	})
+}
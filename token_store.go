@@ -0,0 +1,103 @@
+// token_store.go
+package auth
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// TokenStore persists refresh tokens and denylists revoked access tokens, so
+// Authenticator implementations can support rotation-on-use and
+// logout-everywhere without each reimplementing storage.
+type TokenStore interface {
+	// SaveRefreshToken records that tokenID belongs to userID and expires at expiresAt.
+	SaveRefreshToken(ctx context.Context, userID, tokenID string, expiresAt time.Time) error
+
+	// TakeRefreshToken validates tokenID for userID and, if valid, consumes it
+	// (so it cannot be replayed), returning whether it was valid.
+	TakeRefreshToken(ctx context.Context, userID, tokenID string) (bool, error)
+
+	// RevokeRefreshToken invalidates tokenID ahead of its expiry.
+	RevokeRefreshToken(ctx context.Context, userID, tokenID string) error
+
+	// Denylist marks tokenID as revoked until expiresAt.
+	Denylist(ctx context.Context, tokenID string, expiresAt time.Time) error
+
+	// IsDenylisted reports whether tokenID has been revoked and not yet expired.
+	IsDenylisted(ctx context.Context, tokenID string) (bool, error)
+}
+
+// MemoryTokenStore is an in-memory TokenStore. It is safe for concurrent use
+// but does not persist across process restarts, so it is best suited for
+// tests and single-instance deployments.
+type MemoryTokenStore struct {
+	mu       sync.Mutex
+	refresh  map[string]memoryRefreshEntry // tokenID -> entry
+	denylist map[string]time.Time          // tokenID -> expiresAt
+}
+
+type memoryRefreshEntry struct {
+	userID    string
+	expiresAt time.Time
+}
+
+// NewMemoryTokenStore creates an empty MemoryTokenStore.
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return &MemoryTokenStore{
+		refresh:  make(map[string]memoryRefreshEntry),
+		denylist: make(map[string]time.Time),
+	}
+}
+
+func (m *MemoryTokenStore) SaveRefreshToken(ctx context.Context, userID, tokenID string, expiresAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.refresh[tokenID] = memoryRefreshEntry{userID: userID, expiresAt: expiresAt}
+	return nil
+}
+
+func (m *MemoryTokenStore) TakeRefreshToken(ctx context.Context, userID, tokenID string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, ok := m.refresh[tokenID]
+	if !ok || entry.userID != userID {
+		return false, nil
+	}
+	delete(m.refresh, tokenID)
+
+	if time.Now().After(entry.expiresAt) {
+		return false, nil
+	}
+	return true, nil
+}
+
+func (m *MemoryTokenStore) RevokeRefreshToken(ctx context.Context, userID, tokenID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.refresh, tokenID)
+	return nil
+}
+
+func (m *MemoryTokenStore) Denylist(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.denylist[tokenID] = expiresAt
+	return nil
+}
+
+func (m *MemoryTokenStore) IsDenylisted(ctx context.Context, tokenID string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	expiresAt, ok := m.denylist[tokenID]
+	if !ok {
+		return false, nil
+	}
+	if time.Now().After(expiresAt) {
+		delete(m.denylist, tokenID)
+		return false, nil
+	}
+	return true, nil
+}
@@ -0,0 +1,74 @@
+// token_store_redis.go
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisTokenStore is a TokenStore backed by Redis, suitable for multi-instance
+// deployments where refresh/denylist state must be shared.
+type RedisTokenStore struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewRedisTokenStore creates a RedisTokenStore. Keys are namespaced under
+// prefix (e.g. "goauth:") to avoid colliding with other data in the same
+// Redis instance.
+func NewRedisTokenStore(client *redis.Client, prefix string) *RedisTokenStore {
+	return &RedisTokenStore{client: client, prefix: prefix}
+}
+
+func (r *RedisTokenStore) refreshKey(tokenID string) string {
+	return r.prefix + "refresh:" + tokenID
+}
+
+func (r *RedisTokenStore) denylistKey(tokenID string) string {
+	return r.prefix + "denylist:" + tokenID
+}
+
+func (r *RedisTokenStore) SaveRefreshToken(ctx context.Context, userID, tokenID string, expiresAt time.Time) error {
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+	return r.client.Set(ctx, r.refreshKey(tokenID), userID, ttl).Err()
+}
+
+func (r *RedisTokenStore) TakeRefreshToken(ctx context.Context, userID, tokenID string) (bool, error) {
+	key := r.refreshKey(tokenID)
+
+	// Delete the key only when it belongs to userID, atomically, so a token
+	// presented for the wrong user is not consumed.
+	n, err := r.client.Eval(ctx,
+		`if redis.call("GET", KEYS[1]) == ARGV[1] then return redis.call("DEL", KEYS[1]) end return 0`,
+		[]string{key}, userID).Int()
+	if err != nil {
+		return false, err
+	}
+
+	return n > 0, nil
+}
+
+func (r *RedisTokenStore) RevokeRefreshToken(ctx context.Context, userID, tokenID string) error {
+	return r.client.Del(ctx, r.refreshKey(tokenID)).Err()
+}
+
+func (r *RedisTokenStore) Denylist(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+	return r.client.Set(ctx, r.denylistKey(tokenID), "1", ttl).Err()
+}
+
+func (r *RedisTokenStore) IsDenylisted(ctx context.Context, tokenID string) (bool, error) {
+	n, err := r.client.Exists(ctx, r.denylistKey(tokenID)).Result()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}